@@ -1,6 +1,6 @@
 package main
 
-// make cli && ./bin/walk -walker-uri 'walker://sfomuseum-data/sfomuseum-data-collection?access_token={TOKEN}' data
+// make cli && ./bin/walk -walker-uri 'github://sfomuseum-data/sfomuseum-data-collection?access_token={TOKEN}' data
 
 import (
 	"context"
@@ -13,7 +13,7 @@ import (
 
 func main() {
 
-	walker_uri := flag.String("walker-uri", "", "...")
+	walker_uri := flag.String("walker-uri", "", "A registered walk.Walker URI, for example 'github://{owner}/{repo}?access_token={TOKEN}', 'gitea://{owner}/{repo}?server={URL}' or 'git+file:///path/to/clone'.")
 
 	flag.Parse()
 	uris := flag.Args()
@@ -25,7 +25,7 @@ func main() {
 		return nil
 	}
 
-	w, err := walk.NewGitHubWalker(ctx, *walker_uri)
+	w, err := walk.NewWalker(ctx, *walker_uri)
 
 	if err != nil {
 		log.Fatalf("Failed to create new walker, %v", err)
@@ -0,0 +1,332 @@
+package walk
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"context"
+	"fmt"
+	"github.com/google/go-github/github"
+	"golang.org/x/sync/errgroup"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GiteaWalker is a struct that wraps operations for walking all the files in a Gitea repository. Gitea's REST
+// content API mirrors GitHub's closely enough that entries are translated into the same
+// `*github.RepositoryContent` shape used by the `WalkCallbackFunc` callback.
+type GiteaWalker struct {
+	// A valid Gitea user or organization name.
+	owner string
+	// A valid Gitea repository name.
+	repo string
+	// A valid Gitea repository branch.
+	branch string
+	// A boolean flag indicating whether directory contents should be processed concurrently.
+	concurrent bool
+	// A *gitea.Client instance.
+	client *gitea.Client
+	// A channel used as a semaphore bounding the number of concurrent `WalkURI` invocations across the entire
+	// recursive walk.
+	sem chan struct{}
+	// A *pathFilter used to decide whether an entry should be recursed into or passed to the walk callback.
+	filter *pathFilter
+	// A StateStore instance used to skip files whose blob SHA has not changed since a previous walk, or nil if
+	// the walker is not resumable.
+	store StateStore
+	// A boolean flag indicating whether the state store (if any) should be bypassed and every file processed
+	// regardless of whether its SHA has changed.
+	force bool
+}
+
+// NewGiteaWalker will create a new `GiteaWalker` instance from details defined in uri. uri takes the form of:
+//
+//	gitea://my-org/my-repo?server=https://gitea.example.com&access_token={ACCESS_TOKEN}&concurrent=1
+//
+// Where it's component parts are:
+//
+// scheme: `gitea`.
+// host: A valid Gitea user or organization name.
+// path: A valid Gitea repository name.
+//
+// And it's allowable query parameters are:
+//
+// server: The URL of the Gitea instance to talk to (required).
+// access_token: A valid Gitea API access token.
+// branch: A valid Gitea repository branch to walk.
+// concurrent: A boolean flag indicating whether directory contents should be processed concurrently.
+// workers: The maximum number of concurrent `WalkURI` invocations allowed across the entire recursive walk when `concurrent=1` is set (default 10).
+// include: A comma-separated list of `doublestar`-style glob patterns; a file is only passed to the walk callback if its path matches at least one of these (when specified). Include patterns are not applied to directories, which are recursed into unless excluded.
+// exclude: A comma-separated list of `doublestar`-style glob patterns; an entry whose path matches any of these is pruned entirely, taking precedence over `include`.
+// state: A registered `StateStore` URI (for example `file:///var/lib/walk.db` or `memory://`) used to record processed paths and their blob SHAs, so that a subsequent walk can skip files that have not changed.
+// force: A boolean flag indicating that every file should be passed to the walk callback regardless of what the state store has recorded, bypassing (but still updating) it.
+func NewGiteaWalker(ctx context.Context, uri string) (*GiteaWalker, error) {
+
+	u, err := url.Parse(uri)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse URI, %w", err)
+	}
+
+	gw := &GiteaWalker{}
+
+	gw.owner = u.Host
+
+	path := strings.TrimLeft(u.Path, "/")
+	parts := strings.Split(path, "/")
+
+	if len(parts) != 1 {
+		return nil, fmt.Errorf("Invalid path")
+	}
+
+	gw.repo = parts[0]
+	gw.branch = DEFAULT_BRANCH
+
+	q := u.Query()
+
+	server := q.Get("server")
+	token := q.Get("access_token")
+	branch := q.Get("branch")
+	concurrent := q.Get("concurrent")
+	workers := q.Get("workers")
+	include := q.Get("include")
+	exclude := q.Get("exclude")
+	state := q.Get("state")
+	force := q.Get("force")
+
+	if server == "" {
+		return nil, fmt.Errorf("Missing server parameter")
+	}
+
+	if branch != "" {
+		gw.branch = branch
+	}
+
+	if concurrent != "" {
+
+		c, err := strconv.ParseBool(concurrent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		gw.concurrent = c
+	}
+
+	n_workers := DEFAULT_WORKERS
+
+	if workers != "" {
+
+		w, err := strconv.Atoi(workers)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse workers parameter, %w", err)
+		}
+
+		if w < 1 {
+			return nil, fmt.Errorf("Invalid workers parameter")
+		}
+
+		n_workers = w
+	}
+
+	gw.sem = make(chan struct{}, n_workers)
+	gw.filter = newPathFilter(include, exclude)
+
+	if state != "" {
+
+		store, err := NewStateStore(ctx, state)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create state store, %w", err)
+		}
+
+		gw.store = store
+	}
+
+	if force != "" {
+
+		f, err := strconv.ParseBool(force)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse force parameter, %w", err)
+		}
+
+		gw.force = f
+	}
+
+	client_opts := make([]gitea.ClientOption, 0)
+
+	if token != "" {
+		client_opts = append(client_opts, gitea.SetToken(token))
+	}
+
+	client, err := gitea.NewClient(server, client_opts...)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create Gitea client, %w", err)
+	}
+
+	gw.client = client
+	return gw, nil
+}
+
+// WalkURI will retrieve uri and if it is a file pass it to cb for final processing. If the contents of uri is
+// a directory then each of its children will be processed by calling gw.WalkURI.
+//
+// Unlike GitHub, Gitea has no single endpoint that resolves an arbitrary path as either a file or a directory:
+// `GetContents` only succeeds when uri names a file and `ListContents` only succeeds when it names a directory
+// (calling either with the wrong kind of path returns an "expect file, got directory" / "expect directory, got
+// file" error). So uri is tried as a file first, and only listed as a directory if that fails.
+func (gw *GiteaWalker) WalkURI(ctx context.Context, uri string, cb WalkCallbackFunc) error {
+
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		// pass
+	}
+
+	content, _, err := gw.client.GetContents(gw.owner, gw.repo, gw.branch, uri)
+
+	if err == nil {
+
+		process, err := shouldProcessPath(ctx, gw.store, gw.force, uri, content.SHA)
+
+		if err != nil {
+			return err
+		}
+
+		if !process {
+			return nil
+		}
+
+		rc := giteaContentToGitHub(content)
+
+		err = cb(ctx, rc)
+
+		if err != nil {
+			return fmt.Errorf("Walk callback func failed, %w", err)
+		}
+
+		return recordProcessedPath(ctx, gw.store, uri, content.SHA)
+	}
+
+	entries, _, err := gw.client.ListContents(gw.owner, gw.repo, gw.branch, uri)
+
+	if err != nil {
+		return fmt.Errorf("Failed to get contents for %s, %w", uri, err)
+	}
+
+	if gw.concurrent {
+		return gw.walkDirectoryContentsConcurrently(ctx, entries, cb)
+	}
+
+	return gw.walkDirectoryContents(ctx, entries, cb)
+}
+
+// entryAllowed applies gw.filter to e, using AllowDir for directories so that an include pattern matching only
+// file extensions (e.g. `**/*.json`) doesn't prune every directory on the way down.
+func (gw *GiteaWalker) entryAllowed(e *gitea.ContentsResponse) bool {
+
+	if e.Type == "dir" {
+		return gw.filter.AllowDir(e.Path)
+	}
+
+	return gw.filter.Allow(e.Path)
+}
+
+// walkDirectoryContents will process entries invoking gw.WalkURI for each item.
+func (gw *GiteaWalker) walkDirectoryContents(ctx context.Context, entries []*gitea.ContentsResponse, cb WalkCallbackFunc) error {
+
+	for _, e := range entries {
+
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			// pass
+		}
+
+		if !gw.entryAllowed(e) {
+			continue
+		}
+
+		err := gw.WalkURI(ctx, e.Path, cb)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkDirectoryContentsConcurrently will process entries concurrently invoking gw.WalkURI for each item.
+// Concurrency is bounded by gw.sem, a semaphore shared by every goroutine spawned across the entire recursive
+// walk, not just the children of a single directory.
+func (gw *GiteaWalker) walkDirectoryContentsConcurrently(ctx context.Context, entries []*gitea.ContentsResponse, cb WalkCallbackFunc) error {
+
+	grp, grp_ctx := errgroup.WithContext(ctx)
+
+	for _, e := range entries {
+
+		e := e
+
+		select {
+		case <-grp_ctx.Done():
+			return nil
+		default:
+			// pass
+		}
+
+		if !gw.entryAllowed(e) {
+			continue
+		}
+
+		grp.Go(func() error {
+
+			select {
+			case gw.sem <- struct{}{}:
+				defer func() { <-gw.sem }()
+			case <-grp_ctx.Done():
+				return nil
+			}
+
+			return gw.WalkURI(grp_ctx, e.Path, cb)
+		})
+	}
+
+	return grp.Wait()
+}
+
+// giteaContentToGitHub translates a single Gitea content entry in to the `*github.RepositoryContent` shape
+// expected by a `WalkCallbackFunc`, so callers can consume GitHub and Gitea repositories identically.
+func giteaContentToGitHub(e *gitea.ContentsResponse) *github.RepositoryContent {
+
+	size := int(e.Size)
+
+	return &github.RepositoryContent{
+		Type:     &e.Type,
+		Encoding: e.Encoding,
+		Size:     &size,
+		Name:     &e.Name,
+		Path:     &e.Path,
+		Content:  e.Content,
+		SHA:      &e.SHA,
+	}
+}
+
+func init() {
+
+	ctx := context.Background()
+
+	init_func := func(ctx context.Context, uri string) (Walker, error) {
+		return NewGiteaWalker(ctx, uri)
+	}
+
+	err := RegisterWalker(ctx, "gitea", init_func)
+
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,770 @@
+package walk
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
+	_ "log"
+	"math/rand"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DEFAULT_BRANCH is the assumed default branch for any given GitHub repository.
+const DEFAULT_BRANCH string = "main"
+
+// DEFAULT_WORKERS is the default number of concurrent `WalkURI` invocations allowed across the entire recursive
+// walk, used when the `workers` URI parameter is not specified.
+const DEFAULT_WORKERS int = 10
+
+// DEFAULT_MAX_WAIT is the default ceiling applied to any single throttle back-off, used when the `max-wait` URI
+// parameter is not specified.
+const DEFAULT_MAX_WAIT time.Duration = 5 * time.Minute
+
+// MODE_CONTENTS is the default `GitHubWalker` enumeration mode, issuing one `Repositories.GetContents` call per
+// directory.
+const MODE_CONTENTS string = "contents"
+
+// MODE_TREE is the `GitHubWalker` enumeration mode that issues a single `Git.GetTree` call (with `recursive=1`)
+// to enumerate an entire repository up front, at the cost of not recursing into directories on demand.
+const MODE_TREE string = "tree"
+
+// WalkTreeCallbackFunc defines a custom callback function to be invoked for every entry in a Git tree, without
+// forcing a blob content fetch the way `WalkCallbackFunc` implies. Use this (via `GitHubWalker.WalkTree`) for
+// metadata-only walks, such as path listings, that should cost exactly one API call regardless of repo size.
+type WalkTreeCallbackFunc func(context.Context, *github.TreeEntry) error
+
+// GitHubWalker is a struct that wraps operations for walking all the files in a GitHub repository.
+type GitHubWalker struct {
+	// A valid GitHub user or organization name.
+	owner string
+	// A valid GitHub repository name.
+	repo string
+	// A valid GitHub repository branch.
+	branch string
+	// A boolean flag indicating whether directory contents should be processed concurrently.
+	concurrent bool
+	// The enumeration mode to use, one of `MODE_CONTENTS` or `MODE_TREE`.
+	mode string
+	// A  *github.Client instance
+	client *github.Client
+	// A *apiThrottle instance shared by every goroutine spawned by the walker, used to pace API requests
+	// according to GitHub's rate-limit and abuse-limit signals.
+	throttle *apiThrottle
+	// A channel used as a semaphore bounding the number of concurrent `WalkURI` invocations across the entire
+	// recursive walk, regardless of how many directories are being processed at once.
+	sem chan struct{}
+	// A *pathFilter used to decide whether an entry should be recursed into or passed to the walk callback.
+	filter *pathFilter
+	// A StateStore instance used to skip files whose blob SHA has not changed since a previous walk, or nil if
+	// the walker is not resumable.
+	store StateStore
+	// A boolean flag indicating whether the state store (if any) should be bypassed and every file processed
+	// regardless of whether its SHA has changed.
+	force bool
+	// A boolean flag indicating whether on an GitHub API rate limit error the GitHubWalker should pause until the specified reset time.
+	wait_on_reset bool
+}
+
+// apiThrottle coordinates the pacing of GitHub API requests across every goroutine spawned by a GitHubWalker. It
+// is updated after every response with the `X-RateLimit-Remaining` / `X-RateLimit-Reset` values so that issuance
+// slows down smoothly as the remaining budget approaches zero, and it can be told to back off for a specific
+// duration in response to a secondary (abuse) rate limit or an explicit `Retry-After` header.
+type apiThrottle struct {
+	mu       sync.Mutex
+	min_wait time.Duration
+	max_wait time.Duration
+	next     time.Time
+}
+
+// newAPIThrottle returns an *apiThrottle that never issues requests faster than 5/second and never backs off for
+// longer than max_wait.
+func newAPIThrottle(max_wait time.Duration) *apiThrottle {
+
+	return &apiThrottle{
+		min_wait: time.Second / 5,
+		max_wait: max_wait,
+		next:     time.Now(),
+	}
+}
+
+// wait blocks until it is safe to issue another API request, honouring any back-off previously recorded by
+// update or backoff. It implements a leaky bucket: each caller is assigned the next free slot at least min_wait
+// after the slot handed to the previous caller, rather than everyone racing to read the same t.next and sleeping
+// until it, which would let a burst of callers arriving together all wake at once.
+func (t *apiThrottle) wait(ctx context.Context) error {
+
+	t.mu.Lock()
+
+	now := time.Now()
+	start := t.next
+
+	if now.After(start) {
+		start = now
+	}
+
+	t.next = start.Add(t.min_wait)
+	t.mu.Unlock()
+
+	sleep := time.Until(start)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(sleep):
+		return nil
+	}
+}
+
+// update adjusts the throttle's pacing based on the `X-RateLimit-Remaining` / `X-RateLimit-Reset` values reported
+// in rsp. As remaining approaches zero issuance is slowed so that the remainder of the budget is spread across
+// the time left before reset.
+func (t *apiThrottle) update(rsp *github.Response) {
+
+	if rsp == nil {
+		return
+	}
+
+	remaining := rsp.Rate.Remaining
+	reset := rsp.Rate.Reset.Time
+
+	if remaining <= 0 {
+		t.backoff(time.Until(reset))
+		return
+	}
+
+	until_reset := time.Until(reset)
+
+	if until_reset <= 0 {
+		return
+	}
+
+	per_request := until_reset / time.Duration(remaining)
+
+	if per_request <= t.min_wait {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	next := time.Now().Add(per_request)
+
+	if next.After(t.next) {
+		t.next = next
+	}
+}
+
+// backoff pauses the throttle for d capped at max_wait (when max_wait is positive), plus up to one second of
+// random jitter added after the cap, regardless of what
+// issuance rate update has otherwise calculated. It is used for secondary (abuse) rate limits and explicit
+// `Retry-After` headers.
+func (t *apiThrottle) backoff(d time.Duration) {
+
+	if d < 0 {
+		d = 0
+	}
+
+	if t.max_wait > 0 && d > t.max_wait {
+		d = t.max_wait
+	}
+
+	d += time.Duration(rand.Int63n(int64(time.Second)))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	next := time.Now().Add(d)
+
+	if next.After(t.next) {
+		t.next = next
+	}
+}
+
+// retryAfter returns the duration encoded in a `Retry-After` HTTP header on rsp, if any.
+func retryAfter(rsp *github.Response) (time.Duration, bool) {
+
+	if rsp == nil || rsp.Response == nil {
+		return 0, false
+	}
+
+	h := rsp.Response.Header.Get("Retry-After")
+
+	if h == "" {
+		return 0, false
+	}
+
+	secs, err := strconv.Atoi(h)
+
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(secs) * time.Second, true
+}
+
+// NewGitHubWalker will create a new `GitHubWalker` instance from details defined in uri. uri takes the form of:
+//
+//	github://sfomuseum-data/sfomuseum-data-collection?access_token={ACCESS_TOKEN}&concurrent=1
+//
+// Where it's component part are:
+//
+// scheme: `github`.
+// host: A valid GitHub user or organization name.
+// path: A valid GitHub repository name.
+//
+// And it's allowable query parameters are:
+//
+// access_token: A valid GitHub API access token (required).
+// branch: A valid GitHub repository branch to walk.
+// concurrent: A boolean flag indicating whether directory contents should be processed concurrently.
+// wait-on-reset: A boolean flag indicating whether on an GitHub API rate limit error the GitHubWalker should pause until the specified reset time.
+// max-wait: The maximum number of seconds the walker will sleep for in response to a single rate-limit, abuse-limit or `Retry-After` signal, plus up to one second of random jitter (default 300). A value of 0 or less disables the cap.
+// workers: The maximum number of concurrent `WalkURI` invocations allowed across the entire recursive walk when `concurrent=1` is set (default 10).
+// mode: The enumeration mode to use, one of `contents` (default, one API call per directory) or `tree` (one API call for the entire repository, via the Git Trees API).
+// include: A comma-separated list of `doublestar`-style glob patterns; a file is only passed to the walk callback if its path matches at least one of these (when specified). Include patterns are not applied to directories, which are recursed into unless excluded.
+// exclude: A comma-separated list of `doublestar`-style glob patterns; an entry whose path matches any of these is pruned entirely, taking precedence over `include`.
+// state: A registered `StateStore` URI (for example `file:///var/lib/walk.db` or `memory://`) used to record processed paths and their blob SHAs, so that a subsequent walk can skip files that have not changed.
+// force: A boolean flag indicating that every file should be passed to the walk callback regardless of what the state store has recorded, bypassing (but still updating) it.
+func NewGitHubWalker(ctx context.Context, uri string) (*GitHubWalker, error) {
+
+	u, err := url.Parse(uri)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse URI, %w", err)
+	}
+
+	gw := &GitHubWalker{}
+
+	gw.owner = u.Host
+
+	path := strings.TrimLeft(u.Path, "/")
+	parts := strings.Split(path, "/")
+
+	if len(parts) != 1 {
+		return nil, fmt.Errorf("Invalid path")
+	}
+
+	gw.repo = parts[0]
+	gw.branch = DEFAULT_BRANCH
+	gw.mode = MODE_CONTENTS
+
+	q := u.Query()
+
+	token := q.Get("access_token")
+	branch := q.Get("branch")
+	concurrent := q.Get("concurrent")
+	wait := q.Get("wait-on-reset")
+	max_wait := q.Get("max-wait")
+	workers := q.Get("workers")
+	mode := q.Get("mode")
+	include := q.Get("include")
+	exclude := q.Get("exclude")
+	state := q.Get("state")
+	force := q.Get("force")
+
+	if token == "" {
+		return nil, fmt.Errorf("Missing access token")
+	}
+
+	if branch != "" {
+		gw.branch = branch
+	}
+
+	if mode != "" {
+
+		switch mode {
+		case MODE_CONTENTS, MODE_TREE:
+			gw.mode = mode
+		default:
+			return nil, fmt.Errorf("Invalid mode parameter")
+		}
+	}
+
+	if concurrent != "" {
+
+		c, err := strconv.ParseBool(concurrent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		gw.concurrent = c
+
+	}
+
+	if wait != "" {
+
+		w, err := strconv.ParseBool(wait)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse wait-on-reset parameter, %w", err)
+		}
+
+		gw.wait_on_reset = w
+	}
+
+	max_wait_d := DEFAULT_MAX_WAIT
+
+	if max_wait != "" {
+
+		secs, err := strconv.Atoi(max_wait)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse max-wait parameter, %w", err)
+		}
+
+		max_wait_d = time.Duration(secs) * time.Second
+	}
+
+	gw.throttle = newAPIThrottle(max_wait_d)
+
+	n_workers := DEFAULT_WORKERS
+
+	if workers != "" {
+
+		w, err := strconv.Atoi(workers)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse workers parameter, %w", err)
+		}
+
+		if w < 1 {
+			return nil, fmt.Errorf("Invalid workers parameter")
+		}
+
+		n_workers = w
+	}
+
+	gw.sem = make(chan struct{}, n_workers)
+	gw.filter = newPathFilter(include, exclude)
+
+	if state != "" {
+
+		store, err := NewStateStore(ctx, state)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create state store, %w", err)
+		}
+
+		gw.store = store
+	}
+
+	if force != "" {
+
+		f, err := strconv.ParseBool(force)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse force parameter, %w", err)
+		}
+
+		gw.force = f
+	}
+
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+
+	tc := oauth2.NewClient(ctx, ts)
+	client := github.NewClient(tc)
+
+	gw.client = client
+	return gw, nil
+}
+
+// WalkURI will retrieve uri and if it is a file pass it to cb for final processing. If the contents of uri is
+// a directory then each of its children will be processed by calling gw.WalkURI.
+func (gw *GitHubWalker) WalkURI(ctx context.Context, uri string, cb WalkCallbackFunc) error {
+
+	// fmt.Printf("walk %s\n", uri)
+
+	// log.Printf("Walk %s/%s/%s", gw.owner, gw.repo, uri)
+
+	if gw.mode == MODE_TREE {
+		return gw.walkTree(ctx, uri, cb)
+	}
+
+	err := gw.throttle.wait(ctx)
+
+	if err != nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		// pass
+	}
+
+	// https://pkg.go.dev/github.com/google/go-github/v39/github#hdr-Rate_Limiting
+	// https://docs.github.com/en/rest/overview/resources-in-the-rest-api#rate-limiting
+
+	// https://pkg.go.dev/github.com/google/go-github/v39/github#RepositoriesService.GetContents
+	// https://docs.github.com/en/rest/reference/repos#get-repository-content
+	// https://pkg.go.dev/github.com/google/go-github/v39/github#RepositoryContentGetOptions
+
+	file_contents, dir_contents, rsp, err := gw.client.Repositories.GetContents(ctx, gw.owner, gw.repo, uri, nil)
+
+	gw.throttle.update(rsp)
+
+	/*
+		rate_limit := rsp.Rate.Limit
+		rate_remaining := rsp.Rate.Remaining
+		fmt.Printf("Fetch %s (%d/%d)\n", uri, rate_remaining, rate_limit)
+	*/
+
+	if err != nil {
+
+		if abuse_err, is_abuse_err := err.(*github.AbuseRateLimitError); is_abuse_err {
+
+			retry := time.Minute
+
+			if abuse_err.RetryAfter != nil {
+				retry = *abuse_err.RetryAfter
+			}
+
+			gw.throttle.backoff(retry)
+			return gw.WalkURI(ctx, uri, cb)
+		}
+
+		if _, is_rate_limit_err := err.(*github.RateLimitError); is_rate_limit_err && gw.wait_on_reset {
+
+			if retry, ok := retryAfter(rsp); ok {
+				gw.throttle.backoff(retry)
+			} else {
+				reset := rsp.Rate.Reset.Time
+				gw.throttle.backoff(time.Until(reset))
+			}
+
+			return gw.WalkURI(ctx, uri, cb)
+		}
+
+		return fmt.Errorf("Failed to get contents for %s, %w", uri, err)
+	}
+
+	if file_contents != nil {
+
+		process, err := shouldProcessPath(ctx, gw.store, gw.force, uri, file_contents.GetSHA())
+
+		if err != nil {
+			return err
+		}
+
+		if !process {
+			return nil
+		}
+
+		err = cb(ctx, file_contents)
+
+		if err != nil {
+			return fmt.Errorf("Walk callback func failed, %w", err)
+		}
+
+		return recordProcessedPath(ctx, gw.store, uri, file_contents.GetSHA())
+	}
+
+	if dir_contents != nil {
+
+		if gw.concurrent {
+			return gw.walkDirectoryContentsConcurrently(ctx, dir_contents, cb)
+		} else {
+			return gw.walkDirectoryContents(ctx, dir_contents, cb)
+		}
+	}
+
+	return nil
+}
+
+// entryAllowed applies gw.filter to e, using AllowDir for directories so that an include pattern matching only
+// file extensions (e.g. `**/*.json`) doesn't prune every directory on the way down.
+func (gw *GitHubWalker) entryAllowed(e *github.RepositoryContent) bool {
+
+	if e.GetType() == "dir" {
+		return gw.filter.AllowDir(*e.Path)
+	}
+
+	return gw.filter.Allow(*e.Path)
+}
+
+// walkDirectoryContents will process contents invoking wg.WalkURI for each item.
+func (gw *GitHubWalker) walkDirectoryContents(ctx context.Context, contents []*github.RepositoryContent, cb WalkCallbackFunc) error {
+
+	for _, e := range contents {
+
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			// pass
+		}
+
+		if !gw.entryAllowed(e) {
+			continue
+		}
+
+		err := gw.WalkURI(ctx, *e.Path, cb)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkDirectoryContentsConcurrently will process contents concurrently invoking wg.WalkURI for each item. Concurrency
+// is bounded by gw.sem, a semaphore shared by every goroutine spawned across the entire recursive walk (not just
+// the children of a single directory), so a deeply nested repository can never exceed the configured number of
+// workers in flight at once.
+func (gw *GitHubWalker) walkDirectoryContentsConcurrently(ctx context.Context, contents []*github.RepositoryContent, cb WalkCallbackFunc) error {
+
+	grp, grp_ctx := errgroup.WithContext(ctx)
+
+	for _, e := range contents {
+
+		e := e
+
+		select {
+		case <-grp_ctx.Done():
+			return nil
+		default:
+			// pass
+		}
+
+		if !gw.entryAllowed(e) {
+			continue
+		}
+
+		grp.Go(func() error {
+
+			select {
+			case gw.sem <- struct{}{}:
+				defer func() { <-gw.sem }()
+			case <-grp_ctx.Done():
+				return nil
+			}
+
+			return gw.WalkURI(grp_ctx, *e.Path, cb)
+		})
+	}
+
+	return grp.Wait()
+}
+
+// walkTree implements `MODE_TREE` enumeration: it fetches the repository's tree for gw.branch exactly once via
+// `Git.GetTree` with `recursive=1`, then invokes cb once for every blob beneath uri, synthesizing a
+// `*github.RepositoryContent` from the tree entry directly. The synthesized content has no `Content` set; callers
+// that need blob contents should fetch them lazily with `gw.GetBlobContent`, by SHA, only for the files they
+// actually consume.
+func (gw *GitHubWalker) walkTree(ctx context.Context, uri string, cb WalkCallbackFunc) error {
+
+	err := gw.forEachTreeEntry(ctx, uri, func(ctx context.Context, entry *github.TreeEntry) error {
+
+		if entry.GetType() != "blob" {
+			return nil
+		}
+
+		rc := treeEntryToContent(entry)
+
+		return cb(ctx, rc)
+	})
+
+	return err
+}
+
+// WalkTree enumerates the repository's tree for gw.branch in a single `Git.GetTree` call and invokes cb once for
+// every entry beneath uri ("" for the whole repository), without ever fetching blob contents. This is the
+// cheapest possible way to do a metadata-only walk (e.g. a path listing): it costs exactly one API call
+// regardless of the size of the repository.
+func (gw *GitHubWalker) WalkTree(ctx context.Context, uri string, cb WalkTreeCallbackFunc) error {
+
+	return gw.forEachTreeEntry(ctx, uri, cb)
+}
+
+// forEachTreeEntry fetches gw.branch's tree exactly once and invokes cb for every entry whose path is uri or
+// falls beneath it.
+func (gw *GitHubWalker) forEachTreeEntry(ctx context.Context, uri string, cb WalkTreeCallbackFunc) error {
+
+	err := gw.throttle.wait(ctx)
+
+	if err != nil {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		// pass
+	}
+
+	// https://pkg.go.dev/github.com/google/go-github/github#GitService.GetTree
+	// https://docs.github.com/en/rest/git/trees#get-a-tree
+
+	tree, rsp, err := gw.client.Git.GetTree(ctx, gw.owner, gw.repo, gw.branch, true)
+
+	gw.throttle.update(rsp)
+
+	if err != nil {
+		return fmt.Errorf("Failed to get tree for %s/%s@%s, %w", gw.owner, gw.repo, gw.branch, err)
+	}
+
+	if tree.GetTruncated() {
+		return fmt.Errorf("Tree for %s/%s@%s was truncated by the GitHub API; mode=%s cannot enumerate this repository in a single call", gw.owner, gw.repo, gw.branch, MODE_TREE)
+	}
+
+	prefix := strings.TrimLeft(uri, "/")
+
+	// A recursive tree lists each "tree" entry directly ahead of the entries beneath it, so once a tree entry is
+	// rejected by gw.filter its whole subtree is skipped by path prefix.
+	pruned := ""
+
+	for i := range tree.Entries {
+
+		entry := &tree.Entries[i]
+
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			// pass
+		}
+
+		if !underPath(entry.GetPath(), prefix) {
+			continue
+		}
+
+		if pruned != "" && underPath(entry.GetPath(), pruned) {
+			continue
+		}
+
+		if !gw.treeEntryAllowed(entry) {
+
+			if entry.GetType() == "tree" {
+				pruned = entry.GetPath()
+			}
+
+			continue
+		}
+
+		process, err := shouldProcessPath(ctx, gw.store, gw.force, entry.GetPath(), entry.GetSHA())
+
+		if err != nil {
+			return err
+		}
+
+		if !process {
+			continue
+		}
+
+		err = cb(ctx, entry)
+
+		if err != nil {
+			return fmt.Errorf("Walk callback func failed, %w", err)
+		}
+
+		err = recordProcessedPath(ctx, gw.store, entry.GetPath(), entry.GetSHA())
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+
+// treeEntryAllowed applies gw.filter to entry, using AllowDir for "tree" entries so that an include pattern
+// matching only file extensions doesn't prune every directory on the way down.
+func (gw *GitHubWalker) treeEntryAllowed(entry *github.TreeEntry) bool {
+
+	if entry.GetType() == "tree" {
+		return gw.filter.AllowDir(entry.GetPath())
+	}
+
+	return gw.filter.Allow(entry.GetPath())
+}
+
+// underPath returns true if path is equal to, or a descendant of, prefix. An empty prefix matches every path.
+func underPath(path string, prefix string) bool {
+
+	if prefix == "" {
+		return true
+	}
+
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// treeEntryToContent synthesizes a `*github.RepositoryContent` from a `*github.TreeEntry`, leaving `Content`
+// unset. Callers wanting the blob's contents should fetch it lazily with `GetBlobContent`.
+func treeEntryToContent(entry *github.TreeEntry) *github.RepositoryContent {
+
+	file_type := "file"
+	name := path.Base(entry.GetPath())
+	entry_path := entry.GetPath()
+	sha := entry.GetSHA()
+	size := entry.GetSize()
+
+	return &github.RepositoryContent{
+		Type: &file_type,
+		Name: &name,
+		Path: &entry_path,
+		SHA:  &sha,
+		Size: &size,
+	}
+}
+
+// GetBlobContent fetches and decodes the contents of the blob identified by sha via the Git Blobs API. Use this
+// from a `WalkCallbackFunc` or `WalkTreeCallbackFunc` to lazily fetch content for only the files the caller
+// actually needs, rather than paying for every blob in a `MODE_TREE` walk.
+func (gw *GitHubWalker) GetBlobContent(ctx context.Context, sha string) (string, error) {
+
+	err := gw.throttle.wait(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	blob, rsp, err := gw.client.Git.GetBlob(ctx, gw.owner, gw.repo, sha)
+
+	gw.throttle.update(rsp)
+
+	if err != nil {
+		return "", fmt.Errorf("Failed to get blob %s, %w", sha, err)
+	}
+
+	if blob.GetEncoding() != "base64" {
+		return blob.GetContent(), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(blob.GetContent())
+
+	if err != nil {
+		return "", fmt.Errorf("Failed to decode blob %s, %w", sha, err)
+	}
+
+	return string(decoded), nil
+}
+
+func init() {
+
+	ctx := context.Background()
+
+	init_func := func(ctx context.Context, uri string) (Walker, error) {
+		return NewGitHubWalker(ctx, uri)
+	}
+
+	err := RegisterWalker(ctx, "github", init_func)
+
+	if err != nil {
+		panic(err)
+	}
+}
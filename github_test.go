@@ -0,0 +1,82 @@
+package walk
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAPIThrottleWaitSerializesConcurrentCallers verifies that apiThrottle.wait behaves like a leaky bucket: when
+// a backoff pushes t.next into the future and several goroutines call wait concurrently, each one is still given
+// a wake time at least min_wait after the one before it, rather than every caller waking up in the same burst.
+func TestAPIThrottleWaitSerializesConcurrentCallers(t *testing.T) {
+
+	th := newAPIThrottle(time.Minute)
+	th.backoff(100 * time.Millisecond)
+
+	n := 5
+
+	var mu sync.Mutex
+	var woke []time.Time
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+
+		wg.Add(1)
+
+		go func() {
+
+			defer wg.Done()
+
+			err := th.wait(context.Background())
+
+			if err != nil {
+				t.Errorf("wait returned unexpected error, %v", err)
+				return
+			}
+
+			mu.Lock()
+			woke = append(woke, time.Now())
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if len(woke) != n {
+		t.Fatalf("expected %d wake times, got %d", n, len(woke))
+	}
+
+	sort.Slice(woke, func(i, j int) bool { return woke[i].Before(woke[j]) })
+
+	// Allow a small amount of scheduling slack below min_wait so the test isn't flaky under load.
+	slack := 20 * time.Millisecond
+
+	for i := 1; i < len(woke); i++ {
+
+		gap := woke[i].Sub(woke[i-1])
+
+		if gap < th.min_wait-slack {
+			t.Errorf("wake %d followed wake %d by only %v, expected at least ~%v", i, i-1, gap, th.min_wait)
+		}
+	}
+}
+
+// TestAPIThrottleWaitContextCancellation verifies that wait returns the context's error rather than blocking
+// forever when the context is cancelled before the throttle's wake time.
+func TestAPIThrottleWaitContextCancellation(t *testing.T) {
+
+	th := newAPIThrottle(time.Minute)
+	th.backoff(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := th.wait(ctx)
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
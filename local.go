@@ -0,0 +1,258 @@
+package walk
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/go-github/github"
+	"net/url"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// LocalWalker is a struct that wraps operations for walking all the files in a local clone of a git repository,
+// by shelling out to the `git` binary already on PATH rather than talking to any remote API. This is useful for
+// walking a mirror that has already been cloned, without spending any GitHub (or Gitea) API budget.
+type LocalWalker struct {
+	// The path on disk to the local git clone.
+	clone_path string
+	// The branch (or other ref) to walk.
+	branch string
+	// A *pathFilter used to decide whether an entry should be passed to the walk callback.
+	filter *pathFilter
+	// A StateStore instance used to skip files whose blob SHA has not changed since a previous walk, or nil if
+	// the walker is not resumable.
+	store StateStore
+	// A boolean flag indicating whether the state store (if any) should be bypassed and every file processed
+	// regardless of whether its SHA has changed.
+	force bool
+}
+
+// NewLocalWalker will create a new `LocalWalker` instance from details defined in uri. uri takes the form of:
+//
+//	git+file:///usr/local/data/sfomuseum-data-collection?branch=main
+//
+// Where it's component parts are:
+//
+// scheme: `git+file`.
+// path: The path on disk to a local git clone.
+//
+// And it's allowable query parameters are:
+//
+// branch: The branch (or other ref) to walk (default "main").
+// include: A comma-separated list of `doublestar`-style glob patterns; an entry is only passed to the walk callback if its path matches at least one of these (when specified).
+// exclude: A comma-separated list of `doublestar`-style glob patterns; an entry whose path matches any of these is skipped entirely, taking precedence over `include`.
+// state: A registered `StateStore` URI (for example `file:///var/lib/walk.db` or `memory://`) used to record processed paths and their blob SHAs, so that a subsequent walk can skip files that have not changed.
+// force: A boolean flag indicating that every file should be passed to the walk callback regardless of what the state store has recorded, bypassing (but still updating) it.
+func NewLocalWalker(ctx context.Context, uri string) (*LocalWalker, error) {
+
+	u, err := url.Parse(uri)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse URI, %w", err)
+	}
+
+	if u.Path == "" {
+		return nil, fmt.Errorf("Missing path")
+	}
+
+	lw := &LocalWalker{
+		clone_path: u.Path,
+		branch:     DEFAULT_BRANCH,
+	}
+
+	q := u.Query()
+	branch := q.Get("branch")
+
+	if branch != "" {
+		lw.branch = branch
+	}
+
+	lw.filter = newPathFilter(q.Get("include"), q.Get("exclude"))
+
+	state := q.Get("state")
+
+	if state != "" {
+
+		store, err := NewStateStore(ctx, state)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create state store, %w", err)
+		}
+
+		lw.store = store
+	}
+
+	force := q.Get("force")
+
+	if force != "" {
+
+		f, err := strconv.ParseBool(force)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse force parameter, %w", err)
+		}
+
+		lw.force = f
+	}
+
+	return lw, nil
+}
+
+// WalkURI lists every file reachable from uri (a path relative to the root of the repository, or "" for the
+// whole tree) in lw's branch using `git ls-tree` and invokes cb once for each one.
+func (lw *LocalWalker) WalkURI(ctx context.Context, uri string, cb WalkCallbackFunc) error {
+
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		// pass
+	}
+
+	rel := strings.TrimPrefix(uri, "/")
+
+	// Passing rel as a pathspec (rather than as part of the tree-ish) lets uri name either a directory or a
+	// single file, and makes ls-tree print paths relative to the root of the repository.
+	args := []string{"-C", lw.clone_path, "ls-tree", "-r", "--full-tree", lw.branch}
+
+	if rel != "" {
+		args = append(args, "--", rel)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		return fmt.Errorf("Failed to list tree for %s, %w", uri, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+
+	for _, line := range lines {
+
+		if line == "" {
+			continue
+		}
+
+		entry_path, sha, err := parseTreeEntry("", line)
+
+		if err != nil {
+			return err
+		}
+
+		if !lw.filter.Allow(entry_path) {
+			continue
+		}
+
+		process, err := shouldProcessPath(ctx, lw.store, lw.force, entry_path, sha)
+
+		if err != nil {
+			return err
+		}
+
+		if !process {
+			continue
+		}
+
+		rc, err := lw.entryToContent(ctx, entry_path, sha)
+
+		if err != nil {
+			return err
+		}
+
+		err = cb(ctx, rc)
+
+		if err != nil {
+			return fmt.Errorf("Walk callback func failed, %w", err)
+		}
+
+		err = recordProcessedPath(ctx, lw.store, entry_path, sha)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseTreeEntry parses a single line of `git ls-tree` output (rooted at rel) in to its path and blob SHA.
+func parseTreeEntry(rel string, line string) (string, string, error) {
+
+	// <mode> SP <type> SP <sha>\t<path>
+	fields := strings.SplitN(line, "\t", 2)
+
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("Failed to parse ls-tree entry, %s", line)
+	}
+
+	meta := strings.Fields(fields[0])
+
+	if len(meta) != 3 {
+		return "", "", fmt.Errorf("Failed to parse ls-tree entry, %s", line)
+	}
+
+	sha := meta[2]
+	entry_path := fields[1]
+
+	if rel != "" {
+		entry_path = path.Join(rel, entry_path)
+	}
+
+	return entry_path, sha, nil
+}
+
+// entryToContent fetches the blob contents for sha and returns entry_path and sha as a `*github.RepositoryContent`.
+func (lw *LocalWalker) entryToContent(ctx context.Context, entry_path string, sha string) (*github.RepositoryContent, error) {
+
+	content, err := lw.blob(ctx, sha)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read blob for %s, %w", entry_path, err)
+	}
+
+	file_type := "file"
+	name := path.Base(entry_path)
+
+	rc := &github.RepositoryContent{
+		Type:    &file_type,
+		Name:    &name,
+		Path:    &entry_path,
+		SHA:     &sha,
+		Content: &content,
+	}
+
+	return rc, nil
+}
+
+// blob returns the contents of the git blob identified by sha in lw's local clone.
+func (lw *LocalWalker) blob(ctx context.Context, sha string) (string, error) {
+
+	cmd := exec.CommandContext(ctx, "git", "-C", lw.clone_path, "cat-file", "blob", sha)
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func init() {
+
+	ctx := context.Background()
+
+	init_func := func(ctx context.Context, uri string) (Walker, error) {
+		return NewLocalWalker(ctx, uri)
+	}
+
+	err := RegisterWalker(ctx, "git+file", init_func)
+
+	if err != nil {
+		panic(err)
+	}
+}
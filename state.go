@@ -0,0 +1,108 @@
+package walk
+
+import (
+	"context"
+	"fmt"
+	"github.com/aaronland/go-roster"
+	"net/url"
+)
+
+// StateStore is an interface for recording the last-processed blob SHA for a given path, so that a `Walker` can
+// skip files that have not changed since a previous walk. This turns a walk in to an incremental-sync primitive:
+// on restart, only new or modified files are passed to the walk callback.
+type StateStore interface {
+	// Get returns the SHA last recorded for path, and whether an entry was found for it at all.
+	Get(ctx context.Context, path string) (string, bool, error)
+	// Set records sha as the last-processed SHA for path.
+	Set(ctx context.Context, path string, sha string) error
+}
+
+// StateStoreInitializationFunc defines a common initialization function for instances implementing the
+// `StateStore` interface, used to register implementations by URI scheme with `RegisterStateStore`.
+type StateStoreInitializationFunc func(ctx context.Context, uri string) (StateStore, error)
+
+var state_stores roster.Roster
+
+// RegisterStateStore associates scheme with init_func, a `StateStoreInitializationFunc` used to instantiate new
+// `StateStore` instances for URIs with that scheme. Backend implementations call this from an `init` function.
+func RegisterStateStore(ctx context.Context, scheme string, init_func StateStoreInitializationFunc) error {
+
+	err := ensureStateStoreRoster()
+
+	if err != nil {
+		return err
+	}
+
+	return state_stores.Register(ctx, scheme, init_func)
+}
+
+func ensureStateStoreRoster() error {
+
+	if state_stores == nil {
+
+		r, err := roster.NewDefaultRoster()
+
+		if err != nil {
+			return err
+		}
+
+		state_stores = r
+	}
+
+	return nil
+}
+
+// NewStateStore returns a new `StateStore` instance for uri, dispatching on uri's scheme to whichever
+// `StateStoreInitializationFunc` was registered for it with `RegisterStateStore`.
+func NewStateStore(ctx context.Context, uri string) (StateStore, error) {
+
+	u, err := url.Parse(uri)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse URI, %w", err)
+	}
+
+	scheme := u.Scheme
+
+	i, err := state_stores.Driver(ctx, scheme)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to derive state store for scheme '%s', %w", scheme, err)
+	}
+
+	init_func := i.(StateStoreInitializationFunc)
+	return init_func(ctx, uri)
+}
+
+// shouldProcessPath returns true if the entry at path with the given blob sha should be passed to a walk
+// callback. It is always true when store is nil or force is set; otherwise it is true only if sha differs from
+// (or is absent from) what store last recorded for path. Every `Walker` implementation shares this helper rather
+// than reimplementing the comparison itself.
+func shouldProcessPath(ctx context.Context, store StateStore, force bool, path string, sha string) (bool, error) {
+
+	if store == nil || force {
+		return true, nil
+	}
+
+	prev, ok, err := store.Get(ctx, path)
+
+	if err != nil {
+		return false, fmt.Errorf("Failed to read state for %s, %w", path, err)
+	}
+
+	if ok && prev == sha {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// recordProcessedPath records sha as the last-processed SHA for path, if store is non-nil.
+func recordProcessedPath(ctx context.Context, store StateStore, path string, sha string) error {
+
+	if store == nil {
+		return nil
+	}
+
+	return store.Set(ctx, path, sha)
+}
@@ -0,0 +1,209 @@
+package walk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// FILE_STATE_COMPACT_THRESHOLD is the number of appended records a `FileStateStore` will accumulate since its
+// last compaction before it rewrites the state file in full and starts counting again.
+const FILE_STATE_COMPACT_THRESHOLD int = 1000
+
+// FileStateStore is a `StateStore` implementation backed by a flat, newline-delimited file on disk, each line of
+// which is a "path\tsha" pair. The file is read once at open time; subsequent `Set` calls are appended to an open
+// file handle rather than rewriting the whole file, so the cost of a single `Set` is O(1) instead of O(N). Every
+// `FILE_STATE_COMPACT_THRESHOLD` appends (which accumulate duplicate records for any path updated more than
+// once) the file is compacted back down to one line per path.
+type FileStateStore struct {
+	mu       sync.Mutex
+	path     string
+	shas     map[string]string
+	appendfh *os.File
+	appends  int
+}
+
+// NewFileStateStore creates a new `FileStateStore` instance from details defined in uri. uri takes the form of:
+//
+//	file:///var/lib/walk.db
+//
+// Where path is the location on disk of the state file. The file does not need to already exist.
+func NewFileStateStore(ctx context.Context, uri string) (StateStore, error) {
+
+	u, err := url.Parse(uri)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse URI, %w", err)
+	}
+
+	if u.Path == "" {
+		return nil, fmt.Errorf("Missing path")
+	}
+
+	s := &FileStateStore{
+		path: u.Path,
+		shas: make(map[string]string),
+	}
+
+	err = s.load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.openAppend()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// load populates s.shas from the state file on disk, if it exists.
+func (s *FileStateStore) load() error {
+
+	f, err := os.Open(s.path)
+
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to open %s, %w", s.path, err)
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+
+		line := scanner.Text()
+
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, "\t", 2)
+
+		if len(parts) != 2 {
+			continue
+		}
+
+		s.shas[parts[0]] = parts[1]
+	}
+
+	return scanner.Err()
+}
+
+// openAppend (re)opens s.appendfh for appending, creating the state file if it does not already exist. Callers
+// must hold s.mu.
+func (s *FileStateStore) openAppend() error {
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return fmt.Errorf("Failed to open %s, %w", s.path, err)
+	}
+
+	s.appendfh = f
+	return nil
+}
+
+// Get returns the SHA last recorded for path, and whether an entry was found for it at all.
+func (s *FileStateStore) Get(ctx context.Context, path string) (string, bool, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sha, ok := s.shas[path]
+	return sha, ok, nil
+}
+
+// Set records sha as the last-processed SHA for path, appending the record to the state file. Once
+// `FILE_STATE_COMPACT_THRESHOLD` records have been appended since the last compaction, the file is rewritten in
+// full to collapse duplicate records for paths that were updated more than once.
+func (s *FileStateStore) Set(ctx context.Context, path string, sha string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shas[path] = sha
+
+	_, err := fmt.Fprintf(s.appendfh, "%s\t%s\n", path, sha)
+
+	if err != nil {
+		return fmt.Errorf("Failed to append state for %s, %w", path, err)
+	}
+
+	s.appends += 1
+
+	if s.appends < FILE_STATE_COMPACT_THRESHOLD {
+		return nil
+	}
+
+	return s.compact()
+}
+
+// compact rewrites the state file in full with the current contents of s.shas, one line per path, and resets the
+// append counter. Callers must hold s.mu.
+func (s *FileStateStore) compact() error {
+
+	err := s.appendfh.Close()
+
+	if err != nil {
+		return fmt.Errorf("Failed to close %s, %w", s.path, err)
+	}
+
+	tmp := s.path + ".tmp"
+
+	f, err := os.Create(tmp)
+
+	if err != nil {
+		return fmt.Errorf("Failed to create %s, %w", tmp, err)
+	}
+
+	w := bufio.NewWriter(f)
+
+	for path, sha := range s.shas {
+		fmt.Fprintf(w, "%s\t%s\n", path, sha)
+	}
+
+	err = w.Flush()
+
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("Failed to write %s, %w", tmp, err)
+	}
+
+	err = f.Close()
+
+	if err != nil {
+		return fmt.Errorf("Failed to close %s, %w", tmp, err)
+	}
+
+	err = os.Rename(tmp, s.path)
+
+	if err != nil {
+		return fmt.Errorf("Failed to rename %s, %w", tmp, err)
+	}
+
+	s.appends = 0
+	return s.openAppend()
+}
+
+func init() {
+
+	ctx := context.Background()
+
+	err := RegisterStateStore(ctx, "file", NewFileStateStore)
+
+	if err != nil {
+		panic(err)
+	}
+}
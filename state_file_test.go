@@ -0,0 +1,128 @@
+package walk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileStateStoreRoundTrip verifies that a sha recorded with Set is returned by Get, both before and after
+// reopening the state file from disk.
+func TestFileStateStoreRoundTrip(t *testing.T) {
+
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.db")
+
+	store, err := NewFileStateStore(ctx, "file://"+path)
+
+	if err != nil {
+		t.Fatalf("Failed to create state store, %v", err)
+	}
+
+	err = store.Set(ctx, "data/a.json", "sha-1")
+
+	if err != nil {
+		t.Fatalf("Failed to set state, %v", err)
+	}
+
+	sha, ok, err := store.Get(ctx, "data/a.json")
+
+	if err != nil {
+		t.Fatalf("Failed to get state, %v", err)
+	}
+
+	if !ok || sha != "sha-1" {
+		t.Fatalf("Expected (sha-1, true), got (%s, %v)", sha, ok)
+	}
+
+	reopened, err := NewFileStateStore(ctx, "file://"+path)
+
+	if err != nil {
+		t.Fatalf("Failed to reopen state store, %v", err)
+	}
+
+	sha, ok, err = reopened.Get(ctx, "data/a.json")
+
+	if err != nil {
+		t.Fatalf("Failed to get state after reopen, %v", err)
+	}
+
+	if !ok || sha != "sha-1" {
+		t.Fatalf("Expected (sha-1, true) after reopen, got (%s, %v)", sha, ok)
+	}
+
+	_, ok, err = reopened.Get(ctx, "data/missing.json")
+
+	if err != nil {
+		t.Fatalf("Failed to get state for missing path, %v", err)
+	}
+
+	if ok {
+		t.Fatalf("Expected no entry for data/missing.json")
+	}
+}
+
+// TestFileStateStoreCompaction verifies that once FILE_STATE_COMPACT_THRESHOLD records have been appended, the
+// state file is collapsed back down to one line per path, and that every path's last-written sha still round-trips
+// correctly afterwards.
+func TestFileStateStoreCompaction(t *testing.T) {
+
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.db")
+
+	store, err := NewFileStateStore(ctx, "file://"+path)
+
+	if err != nil {
+		t.Fatalf("Failed to create state store, %v", err)
+	}
+
+	fs := store.(*FileStateStore)
+
+	for i := 0; i < FILE_STATE_COMPACT_THRESHOLD; i++ {
+		err = store.Set(ctx, "data/a.json", fmt.Sprintf("sha-%d", i))
+
+		if err != nil {
+			t.Fatalf("Failed to set state on iteration %d, %v", i, err)
+		}
+	}
+
+	if fs.appends != 0 {
+		t.Fatalf("Expected append counter to reset to 0 after compaction, got %d", fs.appends)
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("Failed to read state file, %v", err)
+	}
+
+	lines := 0
+
+	for _, b := range data {
+		if b == '\n' {
+			lines++
+		}
+	}
+
+	if lines != 1 {
+		t.Fatalf("Expected state file to be compacted to 1 line, got %d", lines)
+	}
+
+	sha, ok, err := store.Get(ctx, "data/a.json")
+
+	if err != nil {
+		t.Fatalf("Failed to get state after compaction, %v", err)
+	}
+
+	expected := fmt.Sprintf("sha-%d", FILE_STATE_COMPACT_THRESHOLD-1)
+
+	if !ok || sha != expected {
+		t.Fatalf("Expected (%s, true) after compaction, got (%s, %v)", expected, sha, ok)
+	}
+}
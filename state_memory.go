@@ -0,0 +1,59 @@
+package walk
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStateStore is an in-memory `StateStore` implementation. State does not survive the lifetime of the
+// process it was created in, so it is mainly useful for testing or for deduplicating work within a single run
+// rather than resuming a walk across restarts.
+type MemoryStateStore struct {
+	mu   sync.RWMutex
+	shas map[string]string
+}
+
+// NewMemoryStateStore creates a new `MemoryStateStore` instance. uri takes the form of:
+//
+//	memory://
+//
+// There are no meaningful query parameters.
+func NewMemoryStateStore(ctx context.Context, uri string) (StateStore, error) {
+
+	s := &MemoryStateStore{
+		shas: make(map[string]string),
+	}
+
+	return s, nil
+}
+
+// Get returns the SHA last recorded for path, and whether an entry was found for it at all.
+func (s *MemoryStateStore) Get(ctx context.Context, path string) (string, bool, error) {
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sha, ok := s.shas[path]
+	return sha, ok, nil
+}
+
+// Set records sha as the last-processed SHA for path.
+func (s *MemoryStateStore) Set(ctx context.Context, path string, sha string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shas[path] = sha
+	return nil
+}
+
+func init() {
+
+	ctx := context.Background()
+
+	err := RegisterStateStore(ctx, "memory", NewMemoryStateStore)
+
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,159 @@
+package walk
+
+import (
+	"context"
+	"fmt"
+	"github.com/aaronland/go-roster"
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/google/go-github/github"
+	"net/url"
+	"strings"
+)
+
+// WalkCallbackFunc defines a custom callback function to be invoked for every file in a repository, regardless
+// of which `Walker` implementation produced it.
+type WalkCallbackFunc func(context.Context, *github.RepositoryContent) error
+
+// Walker is an interface for walking every file reachable from a given URI, invoking a `WalkCallbackFunc` for
+// each one. Concrete implementations are registered by URI scheme with `RegisterWalker` and instantiated with
+// `NewWalker`.
+type Walker interface {
+	// WalkURI will retrieve uri and if it is a file pass it to cb for final processing. If the contents of uri
+	// are a directory then each of its children will be processed in turn.
+	WalkURI(context.Context, string, WalkCallbackFunc) error
+}
+
+// WalkerInitializationFunc defines a common initialization function for instances implementing the `Walker`
+// interface, used to register implementations by URI scheme with `RegisterWalker`.
+type WalkerInitializationFunc func(ctx context.Context, uri string) (Walker, error)
+
+var walkers roster.Roster
+
+// RegisterWalker associates scheme with init_func, a `WalkerInitializationFunc` used to instantiate new `Walker`
+// instances for URIs with that scheme. Backend implementations call this from an `init` function.
+func RegisterWalker(ctx context.Context, scheme string, init_func WalkerInitializationFunc) error {
+
+	err := ensureWalkerRoster()
+
+	if err != nil {
+		return err
+	}
+
+	return walkers.Register(ctx, scheme, init_func)
+}
+
+func ensureWalkerRoster() error {
+
+	if walkers == nil {
+
+		r, err := roster.NewDefaultRoster()
+
+		if err != nil {
+			return err
+		}
+
+		walkers = r
+	}
+
+	return nil
+}
+
+// NewWalker returns a new `Walker` instance for uri, dispatching on uri's scheme to whichever
+// `WalkerInitializationFunc` was registered for it with `RegisterWalker`.
+func NewWalker(ctx context.Context, uri string) (Walker, error) {
+
+	u, err := url.Parse(uri)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse URI, %w", err)
+	}
+
+	scheme := u.Scheme
+
+	i, err := walkers.Driver(ctx, scheme)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to derive walker for scheme '%s', %w", scheme, err)
+	}
+
+	init_func := i.(WalkerInitializationFunc)
+	return init_func(ctx, uri)
+}
+
+// pathFilter evaluates an entry's path against a set of include and exclude glob patterns before a `Walker`
+// recurses into it or invokes a callback for it. It is shared by every `Walker` implementation so that `include`
+// and `exclude` URI parameters behave identically across backends.
+type pathFilter struct {
+	include []string
+	exclude []string
+}
+
+// newPathFilter builds a *pathFilter from the raw, comma-separated values of the `include` and `exclude` URI
+// parameters. Either (or both) may be empty.
+func newPathFilter(include string, exclude string) *pathFilter {
+
+	pf := &pathFilter{}
+
+	if include != "" {
+		pf.include = strings.Split(include, ",")
+	}
+
+	if exclude != "" {
+		pf.exclude = strings.Split(exclude, ",")
+	}
+
+	return pf
+}
+
+// Allow returns true if the file at path should be passed to a walk callback: it must not match any exclude
+// pattern and, if any include patterns are defined, it must match at least one of them. A nil *pathFilter allows
+// every path. Patterns are matched `doublestar`-style, so `**` matches across path separators.
+func (pf *pathFilter) Allow(path string) bool {
+
+	if pf == nil {
+		return true
+	}
+
+	if pf.excluded(path) {
+		return false
+	}
+
+	if len(pf.include) == 0 {
+		return true
+	}
+
+	for _, pattern := range pf.include {
+
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowDir returns true if the directory at path should be recursed into. Unlike Allow, it does not apply
+// include patterns: a directory's own path rarely matches an extension-style include pattern (e.g. `**/*.json`)
+// even though files beneath it might, so the include decision is deferred to each leaf entry found while
+// recursing. Only exclude patterns, which are expected to prune whole subtrees, are applied here.
+func (pf *pathFilter) AllowDir(path string) bool {
+
+	if pf == nil {
+		return true
+	}
+
+	return !pf.excluded(path)
+}
+
+// excluded returns true if path matches any exclude pattern.
+func (pf *pathFilter) excluded(path string) bool {
+
+	for _, pattern := range pf.exclude {
+
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package walk
+
+import (
+	"testing"
+)
+
+// TestPathFilterAllow exercises the include/exclude semantics a `Walker` applies to a file's path before passing
+// it to a walk callback.
+func TestPathFilterAllow(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		include string
+		exclude string
+		path    string
+		want    bool
+	}{
+		{"empty filter allows everything", "", "", "data/a.json", true},
+		{"include match", "**/*.json", "", "data/a.json", true},
+		{"include miss", "**/*.json", "", "data/a.csv", false},
+		{"exclude takes precedence over include", "**/*.json", "data/**", "data/a.json", false},
+		{"exclude only, no include", "", "vendor/**", "vendor/lib.go", false},
+		{"exclude only, non-matching path allowed", "", "vendor/**", "main.go", true},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+
+			pf := newPathFilter(tt.include, tt.exclude)
+
+			got := pf.Allow(tt.path)
+
+			if got != tt.want {
+				t.Errorf("Allow(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPathFilterAllowDir verifies that AllowDir, unlike Allow, does not apply include patterns, so an
+// extension-style include pattern doesn't prune every directory on the way down to a matching leaf.
+func TestPathFilterAllowDir(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		include string
+		exclude string
+		path    string
+		want    bool
+	}{
+		{"empty filter allows everything", "", "", "data", true},
+		{"include pattern does not prune directories", "**/*.json", "", "data", true},
+		{"exclude still prunes directories", "**/*.json", "data/**", "data", false},
+		{"exclude miss", "", "vendor/**", "data", true},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+
+			pf := newPathFilter(tt.include, tt.exclude)
+
+			got := pf.AllowDir(tt.path)
+
+			if got != tt.want {
+				t.Errorf("AllowDir(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}